@@ -1,6 +1,8 @@
 package srp
 
 import (
+	"crypto/subtle"
+	"errors"
 	"math/big"
 )
 
@@ -11,23 +13,27 @@ type Client struct {
 	P      []byte
 	a      []byte
 	A      []byte
+	s      []byte
+	B      []byte
+	S      []byte
 }
 
 // NewClient returns a new client, with the given credentials
 func NewClient(I []byte, P []byte) *Client {
-	p := NewDefaultParams()
 	return &Client{
-		params: p,
+		params: NewDefaultParams().Clone(),
 		I:      I,
 		P:      P,
 	}
 }
 
 // NewClientWithParams returns a new client, with the given credentials,
-// using the custom group and hash parameters
+// using the custom group and hash parameters. p is cloned, so the caller is
+// free to mutate the Params they passed in (or reuse a bundled Group*
+// value) without affecting this client.
 func NewClientWithParams(p *Params, I []byte, P []byte) *Client {
 	return &Client{
-		params: p,
+		params: p.Clone(),
 		I:      I,
 		P:      P,
 	}
@@ -41,21 +47,61 @@ func (c *Client) SetCredentials(I string, P string) []byte {
 }
 
 // GenerateA computes and returns the Client's chalange A (in the form A := g^a % N,
-// for a := random()), used during the Key Exchange part of the protocol
+// for a := random()), used during the Key Exchange part of the protocol.
+// a is drawn from crypto/rand, as described in randPrivateValue.
 func (c *Client) GenerateA() []byte {
-	c.a = getRandomBytes(3)
-	a := big.NewInt(0).SetBytes(c.a)
+	n := c.params.N()
+	a := randPrivateValue(n)
+	c.a = a.Bytes()
 	g := big.NewInt(c.params.g)
-	A := new(big.Int)
-	c.A = A.Exp(g, a, c.params.N).Bytes()
+	c.A = new(big.Int).Exp(g, a, n).Bytes()
 	return c.A
 }
 
-// SessionKey computes and returns the SRP Session key, defined as
-// S := (B - (k*g^x))^(a + (u*x)) % N
-func (c *Client) SessionKey() ([]byte, []byte) {
-	return nil, nil
+// SessionKey computes and returns the client's premaster secret, defined as
+//
+//	x := H(s | H(I | ":" | P))
+//	u := H(PAD(A) | PAD(B))
+//	S := (B - (k*g^x))^(a + (u*x)) % N
+//
+// It aborts with an error if B mod N == 0.
+func (c *Client) SessionKey(salt, B []byte) ([]byte, error) {
+	n := c.params.N()
+
+	Bn := new(big.Int).SetBytes(B)
+	if new(big.Int).Mod(Bn, n).Sign() == 0 {
+		return nil, errors.New("srp: abort: B mod N == 0")
+	}
+	c.s = salt
+	c.B = B
+
+	g := big.NewInt(c.params.g)
+	k := SRPMultiplier(g, n, c.params.H)
+	x := computeX(c.params, c.I, c.P, salt)
+	An := new(big.Int).SetBytes(c.A)
+	u := SRPu(An, Bn, n, c.params.H)
+	a := new(big.Int).SetBytes(c.a)
+
+	c.S = SRPClientSecret(a, u, Bn, k, x, g, n).Bytes()
+	return c.S, nil
 }
 
-//var n int64 = 97
-//s := strconv.FormatInt(n, 16) // s == "61" (hexadecimal)
+// M1 computes and returns the client's evidence message, proving to the
+// server that it derived the same session key.
+func (c *Client) M1() []byte {
+	return computeM1(c.params, c.I, c.s, c.A, c.B, c.S)
+}
+
+// VerifyM2 checks the server's evidence message against the client's own
+// computation, in constant time.
+func (c *Client) VerifyM2(m2 []byte) bool {
+	expected := computeM2(c.params, c.A, c.M1(), c.S)
+	return subtle.ConstantTimeCompare(expected, m2) == 1
+}
+
+// Key returns the session key K, derived from the premaster secret S by
+// the client's configured Params.KDF. It is only meaningful after
+// SessionKey has succeeded.
+func (c *Client) Key() []byte {
+	return c.params.deriveKey(c.S)
+}
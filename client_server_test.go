@@ -0,0 +1,103 @@
+package srp
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestClientServerHandshake(t *testing.T) {
+	I, P := []byte("alice"), []byte("password123")
+
+	reg := NewClient(I, P)
+	salt, verifier, err := reg.GenerateVerifier()
+	if err != nil {
+		t.Fatalf("GenerateVerifier: %v", err)
+	}
+
+	client := NewClient(I, P)
+	server := NewServer(I, verifier, salt)
+
+	B := server.GenerateB()
+	A := client.GenerateA()
+
+	clientS, err := client.SessionKey(salt, B)
+	if err != nil {
+		t.Fatalf("client.SessionKey: %v", err)
+	}
+	serverS, err := server.ComputeS(A)
+	if err != nil {
+		t.Fatalf("server.ComputeS: %v", err)
+	}
+	if string(clientS) != string(serverS) {
+		t.Fatalf("premaster secrets differ:\nclient S = %X\nserver S = %X", clientS, serverS)
+	}
+
+	m1 := client.M1()
+	if !server.VerifyM1(m1) {
+		t.Fatal("server rejected a genuine client M1")
+	}
+	m2 := server.M2()
+	if !client.VerifyM2(m2) {
+		t.Fatal("client rejected a genuine server M2")
+	}
+
+	if string(client.Key()) != string(server.Key()) {
+		t.Fatalf("derived session keys differ:\nclient K = %X\nserver K = %X", client.Key(), server.Key())
+	}
+}
+
+func TestServerRejectsWrongM1(t *testing.T) {
+	I, P := []byte("alice"), []byte("password123")
+	client := NewClient(I, P)
+	salt, verifier, _ := client.GenerateVerifier()
+	server := NewServer(I, verifier, salt)
+
+	B := server.GenerateB()
+	A := client.GenerateA()
+	if _, err := client.SessionKey(salt, B); err != nil {
+		t.Fatalf("client.SessionKey: %v", err)
+	}
+	if _, err := server.ComputeS(A); err != nil {
+		t.Fatalf("server.ComputeS: %v", err)
+	}
+
+	forged := append([]byte(nil), client.M1()...)
+	forged[0] ^= 0xFF
+	if server.VerifyM1(forged) {
+		t.Fatal("server accepted a forged M1")
+	}
+}
+
+// TestServerComputeSAbortsOnZeroA guards against the classic SRP zero-key
+// attack: a malicious client sending A == 0 (mod N) would let it predict
+// the premaster secret S without knowing the password.
+func TestServerComputeSAbortsOnZeroA(t *testing.T) {
+	I, P := []byte("alice"), []byte("password123")
+	client := NewClient(I, P)
+	salt, verifier, _ := client.GenerateVerifier()
+	server := NewServer(I, verifier, salt)
+	server.GenerateB()
+
+	n := NewDefaultParams().N()
+	for _, A := range [][]byte{{0}, n.Bytes(), new(big.Int).Mul(n, big.NewInt(2)).Bytes()} {
+		if _, err := server.ComputeS(A); err == nil {
+			t.Fatalf("ComputeS(%X) = nil error, want an abort for A mod N == 0", A)
+		}
+	}
+}
+
+// TestClientSessionKeyAbortsOnZeroB mirrors the server-side check: a
+// malicious server sending B == 0 (mod N) must not be accepted.
+func TestClientSessionKeyAbortsOnZeroB(t *testing.T) {
+	I, P := []byte("alice"), []byte("password123")
+	client := NewClient(I, P)
+	salt, _, _ := client.GenerateVerifier()
+	client.GenerateA()
+
+	n := NewDefaultParams().N()
+	for _, B := range [][]byte{{0}, n.Bytes(), new(big.Int).Mul(n, big.NewInt(3)).Bytes()} {
+		if _, err := client.SessionKey(salt, B); err == nil {
+			t.Fatalf("SessionKey(salt, %X) = nil error, want an abort for B mod N == 0", B)
+		}
+	}
+}
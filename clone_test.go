@@ -0,0 +1,25 @@
+package srp
+
+import "testing"
+
+// TestParamsCloneIsolatesKDF guards against the data race where setting KDF
+// on a Params passed to NewClientWithParams/NewServerWithParams would
+// otherwise mutate a shared bundled Group* value used by other concurrent
+// Clients/Servers.
+func TestParamsCloneIsolatesKDF(t *testing.T) {
+	shared := Group2048
+	before := shared.KDF
+
+	c := NewClientWithParams(shared, []byte("alice"), []byte("password123"))
+	c.params.KDF = KDFHKDFExpand
+
+	if shared.KDF != before {
+		t.Fatalf("mutating the client's cloned Params changed the shared group's KDF: %v", shared.KDF)
+	}
+
+	s := NewServerWithParams(shared, []byte("alice"), []byte{0x01}, []byte{0x02})
+	s.params.KDFInfo = []byte("session-info")
+	if len(shared.KDFInfo) != 0 {
+		t.Fatalf("mutating the server's cloned Params changed the shared group's KDFInfo: %v", shared.KDFInfo)
+	}
+}
@@ -60,10 +60,60 @@ protocol descrition:
 	x := H(s | H(I | ":" | P))
 	u := H(PAD(A) | PAD(B))
 	S := (B - (k*g^x))^(a + (u*x)) % N
+	M1 := H(H(N) XOR H(g) | H(I) | s | A | B | K)
+	Client Proof (M1)			-------->
+											(abort if M1 does not match)
+											M2 := H(A | M1 | K)
+								<--------	Server Proof (M2)
+	(abort if M2 does not match)
 
+K, above, is the session key derived from S; see "Session key derivation"
+below.
+
+# Verifier generation
+
+Client.GenerateVerifier computes the (salt, verifier) pair a client sends
+a registration server, matching the "Client registration (s, v)" message
+above. ComputeVerifier exposes the same computation as a package-level
+function for a registration server to validate or regenerate a verifier
+without going through a Client. NewServer and NewServerWithParams take the
+resulting v and s directly.
+
+# Mutual authentication
+
+Client.M1 and Server.VerifyM1 implement the Client Proof message above;
+Server.M2 and Client.VerifyM2 implement the Server Proof message. Both
+verifications run in constant time.
+
+# Session key derivation
+
+S, the raw premaster secret, is never used as the session key directly.
+Client.Key and Server.Key derive K from S according to Params.KDF: the
+default KDFLegacy reproduces RFC 2945's K := H(S); KDFInterleaved and
+KDFHKDFExpand are pluggable alternatives producing a longer or
+differently-derived K. Params.Clone lets a caller change KDF (or KDFInfo)
+on a bundled Group* value without affecting other Clients/Servers sharing
+that group.
+
+# Multi-user servers
+
+UserStore and AuthServer separate the transport/connection lifecycle from
+the Server state machine above: an AuthServer looks up a user's (s, v) in
+a UserStore, generates a fresh (b, B) pair, and returns a ServerSession
+wrapping a Server ready to receive the client's A. InMemoryStore is a
+UserStore suitable for tests and small deployments.
+
+# Wire format
+
+ClientHello, ServerHello, ClientKeyExchange, ClientProof and ServerProof,
+defined in wire.go, marshal the five protocol messages above to a
+self-describing, length-prefixed binary form, for programs that want to
+run this protocol over a stream transport without designing their own
+framing.
 
 Differences between this implementation and RFC 5054:
 * We allow differente choices of Hash function, while the RFC defines SHA-1
-* W
+* We allow the session key derivation (Params.KDF) to be swapped out; the
+  RFC only defines K := H(S)
 */
 package srp
@@ -0,0 +1,34 @@
+package srp
+
+import "math/big"
+
+// computeM1 computes the RFC 2945 client evidence message:
+//
+//	M1 = H(H(N) XOR H(g) | H(I) | s | A | B | K), with K derived from S
+//	by the group's configured Params.KDF
+func computeM1(p *Params, I, salt, A, B, S []byte) []byte {
+	hn := p.hash(p.N().Bytes())
+	hg := p.hash(big.NewInt(p.g).Bytes())
+	hi := p.hash(I)
+	k := p.deriveKey(S)
+	return p.hash(xorBytes(hn, hg), hi, salt, A, B, k)
+}
+
+// computeM2 computes the RFC 2945 server evidence message:
+//
+//	M2 = H(A | M1 | K), with K derived from S by the group's configured
+//	Params.KDF
+func computeM2(p *Params, A, m1, S []byte) []byte {
+	k := p.deriveKey(S)
+	return p.hash(A, m1, k)
+}
+
+// xorBytes returns the byte-wise XOR of a and b, which must be the same
+// length (true for H(N) and H(g), both outputs of the same hash function).
+func xorBytes(a, b []byte) []byte {
+	out := make([]byte, len(a))
+	for i := range a {
+		out[i] = a[i] ^ b[i]
+	}
+	return out
+}
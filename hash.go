@@ -0,0 +1,13 @@
+package srp
+
+import (
+	// register the hash implementations the package's Params may select
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+)
+
+// hash returns H(data...): the concatenation of data, hashed with the
+// group's configured hash function.
+func (p *Params) hash(data ...[]byte) []byte {
+	return hashWith(p.H, data...)
+}
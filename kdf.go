@@ -0,0 +1,88 @@
+package srp
+
+import (
+	"crypto"
+	"crypto/hmac"
+)
+
+// KDF selects how a Params derives the session key K from the premaster
+// secret S.
+type KDF int
+
+const (
+	// KDFLegacy derives K := H(S), the session key from RFC 2945 §3.
+	// This is the default, used by Group1024 .. Group8192.
+	KDFLegacy KDF = iota
+
+	// KDFInterleaved derives K via SHA_Interleave(S) (RFC 2945 §3.1),
+	// which doubles the output length of H by hashing the even- and
+	// odd-indexed bytes of S separately and interleaving the results.
+	KDFInterleaved
+
+	// KDFHKDFExpand derives K via the Expand step of RFC 5869 HKDF,
+	// using S as the pseudorandom key and Params.KDFInfo as the info
+	// label. S is already the output of a Diffie-Hellman-style exchange,
+	// so the Extract step is skipped.
+	KDFHKDFExpand
+)
+
+// deriveKey computes the session key K from the premaster secret S,
+// according to p.KDF.
+func (p *Params) deriveKey(S []byte) []byte {
+	switch p.KDF {
+	case KDFInterleaved:
+		return shaInterleave(p.H, S)
+	case KDFHKDFExpand:
+		return hkdfExpand(p.H, S, p.KDFInfo, p.H.Size())
+	default:
+		return p.hash(S)
+	}
+}
+
+// shaInterleave implements SHA_Interleave(S) from RFC 2945 §3.1: strip
+// leading zero bytes from S, split the remainder into its even- and
+// odd-indexed bytes, hash each half separately, and interleave the two
+// digests byte by byte.
+func shaInterleave(h crypto.Hash, S []byte) []byte {
+	t := S
+	for len(t) > 0 && t[0] == 0 {
+		t = t[1:]
+	}
+	if len(t)%2 == 1 {
+		t = t[1:]
+	}
+
+	even := make([]byte, 0, len(t)/2)
+	odd := make([]byte, 0, len(t)/2)
+	for i := 0; i < len(t); i += 2 {
+		even = append(even, t[i])
+		odd = append(odd, t[i+1])
+	}
+
+	eg := hashWith(h, even)
+	eh := hashWith(h, odd)
+	out := make([]byte, 0, len(eg)+len(eh))
+	for i := range eg {
+		out = append(out, eg[i], eh[i])
+	}
+	return out
+}
+
+// hkdfExpand implements the Expand step of RFC 5869 HKDF: HMAC-H keyed by
+// prk, fed the previous block, info and a one-byte counter, repeated until
+// length bytes have been produced.
+func hkdfExpand(h crypto.Hash, prk, info []byte, length int) []byte {
+	hashLen := h.Size()
+	n := (length + hashLen - 1) / hashLen
+
+	var t, out []byte
+	for i := 1; i <= n; i++ {
+		mac := hmac.New(h.New, prk)
+		mac.Write(t)
+		mac.Write(info)
+		mac.Write([]byte{byte(i)})
+		t = mac.Sum(nil)
+		out = append(out, t...)
+	}
+	return out[:length]
+}
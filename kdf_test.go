@@ -0,0 +1,83 @@
+package srp
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/hex"
+	"testing"
+)
+
+func TestDeriveKeyLegacyIsPlainHash(t *testing.T) {
+	p := NewParams(vecN, 2, crypto.SHA1)
+	S := []byte{0x01, 0x02, 0x03}
+	if got, want := p.deriveKey(S), p.hash(S); !bytes.Equal(got, want) {
+		t.Fatalf("deriveKey(KDFLegacy) = %X, want H(S) = %X", got, want)
+	}
+}
+
+// TestDeriveKeyInterleaved checks shaInterleave (RFC 2945 §3.1) against a
+// value independently computed from the RFC's algorithm description: strip
+// leading zero bytes and an odd leftover byte, hash the even- and
+// odd-indexed bytes of the remainder separately, then interleave.
+func TestDeriveKeyInterleaved(t *testing.T) {
+	p := NewParams(vecN, 2, crypto.SHA1)
+	p.KDF = KDFInterleaved
+
+	S, _ := hex.DecodeString("00ABCDEF0123")
+	want, _ := hex.DecodeString("5e96df89c446f2ebf76f67727a7bb12817c4d9a762889c18ce14dcd35f5a3f7f4d4131703c06b5bf")
+
+	got := p.deriveKey(S)
+	if !bytes.Equal(got, want) {
+		t.Fatalf("deriveKey(KDFInterleaved) = %X, want %X", got, want)
+	}
+	if len(got) != 2*crypto.SHA1.Size() {
+		t.Fatalf("deriveKey(KDFInterleaved) length = %d, want %d (2*H.Size())", len(got), 2*crypto.SHA1.Size())
+	}
+}
+
+// TestDeriveKeyHKDFExpand checks hkdfExpand against RFC 5869 Test Case 1
+// (SHA-256), applied directly to the Extract output PRK since Params.KDF
+// skips the Extract step and treats S itself as the pseudorandom key.
+func TestDeriveKeyHKDFExpand(t *testing.T) {
+	p := NewParams(vecN, 2, crypto.SHA256)
+	p.KDF = KDFHKDFExpand
+
+	prk, _ := hex.DecodeString("077709362c2e32df0ddc3f0dc47bba6390b6c73bb50f9c3122ec844ad7c2b3e5")
+	info, _ := hex.DecodeString("f0f1f2f3f4f5f6f7f8f9")
+	p.KDFInfo = info
+
+	got := p.deriveKey(prk)
+	if len(got) != crypto.SHA256.Size() {
+		t.Fatalf("deriveKey(KDFHKDFExpand) length = %d, want %d (H.Size())", len(got), crypto.SHA256.Size())
+	}
+
+	full := hkdfExpand(crypto.SHA256, prk, info, 42)
+	want, _ := hex.DecodeString("3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865")
+	if !bytes.Equal(full, want) {
+		t.Fatalf("hkdfExpand(..., 42) = %X, want RFC 5869 Test Case 1 OKM %X", full, want)
+	}
+}
+
+func TestDeriveKeyDiffersAcrossKDFs(t *testing.T) {
+	S := []byte{0x01, 0x02, 0x03, 0x04}
+	legacy := NewParams(vecN, 2, crypto.SHA1)
+	interleaved := NewParams(vecN, 2, crypto.SHA1)
+	interleaved.KDF = KDFInterleaved
+	hkdf := NewParams(vecN, 2, crypto.SHA1)
+	hkdf.KDF = KDFHKDFExpand
+	hkdf.KDFInfo = []byte("session")
+
+	kLegacy := legacy.deriveKey(S)
+	kInterleaved := interleaved.deriveKey(S)
+	kHKDF := hkdf.deriveKey(S)
+
+	if bytes.Equal(kLegacy, kInterleaved) {
+		t.Error("KDFLegacy and KDFInterleaved produced the same key")
+	}
+	if bytes.Equal(kLegacy, kHKDF) {
+		t.Error("KDFLegacy and KDFHKDFExpand produced the same key")
+	}
+	if bytes.Equal(kInterleaved, kHKDF) {
+		t.Error("KDFInterleaved and KDFHKDFExpand produced the same key")
+	}
+}
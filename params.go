@@ -0,0 +1,162 @@
+package srp
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// primeCertainty is the number of Miller-Rabin rounds used when checking
+// primality of a group modulus. ProbablyPrime(primeCertainty) gives an error
+// probability of at most 1/4^primeCertainty for the random, non-adversarial
+// inputs this package validates.
+const primeCertainty = 20
+
+// Params holds the group parameters (prime modulus N and generator g), the
+// hash function H used throughout the protocol, and the KDF used to turn
+// the premaster secret S into the session key K.
+type Params struct {
+	g int64
+	H crypto.Hash
+
+	// KDF selects how the session key K is derived from S. The zero value,
+	// KDFLegacy, reproduces the plain RFC 2945 behaviour.
+	KDF KDF
+	// KDFInfo is the info label passed to HKDF-Expand when KDF is
+	// KDFHKDFExpand. Unused by the other KDFs.
+	KDFInfo []byte
+
+	nOnce sync.Once
+	nHex  string
+	n     *big.Int
+}
+
+// NewParams builds a custom set of group parameters from an already parsed
+// modulus N, generator g and hash function h, using the default KDFLegacy
+// key derivation. Callers supplying their own group should run Validate on
+// the result before using it.
+func NewParams(N *big.Int, g int64, h crypto.Hash) *Params {
+	return &Params{n: N, g: g, H: h}
+}
+
+// NewDefaultParams returns the package's default group and hash: the
+// RFC 5054 2048-bit group with SHA-1, as recommended by the RFC.
+func NewDefaultParams() *Params {
+	return Group2048
+}
+
+// newGroup builds one of the bundled RFC 5054 Appendix A groups. Parsing of
+// the modulus hex is deferred to the first call to N(), so a program that
+// never uses a given group never pays its big.Int parsing cost.
+func newGroup(nHex string, g int64) *Params {
+	return &Params{nHex: nHex, g: g, H: crypto.SHA1}
+}
+
+// Bundled RFC 5054 Appendix A groups, ready to pass to NewClientWithParams
+// or NewServerWithParams. Select one dynamically by bit size with
+// GroupByBits. Generators match RFC 5054 Appendix A: g = 2 for the
+// 1024/1536/2048-bit groups, g = 5 for the 3072/4096/6144-bit groups
+// (g = 2 is not a full-order generator for those moduli), and g = 19 for
+// the 8192-bit group.
+var (
+	Group1024 = newGroup(n1024Hex, 2)
+	Group1536 = newGroup(n1536Hex, 2)
+	Group2048 = newGroup(n2048Hex, 2)
+	Group3072 = newGroup(n3072Hex, 5)
+	Group4096 = newGroup(n4096Hex, 5)
+	Group6144 = newGroup(n6144Hex, 5)
+	Group8192 = newGroup(n8192Hex, 19)
+)
+
+// GroupByBits returns the bundled RFC 5054 group matching the given modulus
+// size, for callers that select a group dynamically (e.g. from config).
+func GroupByBits(bits int) (*Params, error) {
+	switch bits {
+	case 1024:
+		return Group1024, nil
+	case 1536:
+		return Group1536, nil
+	case 2048:
+		return Group2048, nil
+	case 3072:
+		return Group3072, nil
+	case 4096:
+		return Group4096, nil
+	case 6144:
+		return Group6144, nil
+	case 8192:
+		return Group8192, nil
+	default:
+		return nil, fmt.Errorf("srp: no bundled group for a %d-bit modulus", bits)
+	}
+}
+
+// N returns the group modulus, parsing it from its compiled-in hex form on
+// first use.
+func (p *Params) N() *big.Int {
+	p.nOnce.Do(func() {
+		if p.n != nil {
+			return
+		}
+		n, ok := new(big.Int).SetString(p.nHex, 16)
+		if !ok {
+			panic("srp: invalid group modulus")
+		}
+		p.n = n
+	})
+	return p.n
+}
+
+// Clone returns an independent copy of p, safe to mutate (e.g. to set KDF
+// or KDFInfo) without affecting p or anything else sharing it. The bundled
+// Group* values and anything returned by NewDefaultParams/GroupByBits are
+// package-level singletons shared by every caller, so NewClientWithParams,
+// NewServerWithParams and NewAuthServerWithParams clone the Params they are
+// given rather than aliasing it.
+func (p *Params) Clone() *Params {
+	return &Params{
+		g:       p.g,
+		H:       p.H,
+		KDF:     p.KDF,
+		KDFInfo: append([]byte(nil), p.KDFInfo...),
+		n:       p.N(),
+	}
+}
+
+// Validate checks that N is prime and that g is a generator modulo N, as
+// required of an SRP group. Bundled groups are known-good; this is meant
+// for groups supplied by the caller. The generator check is only complete
+// for a safe prime N = 2q+1: Validate does not test q for primality, so for
+// an N that is prime but not safe it may accept a g that is not a generator.
+func (p *Params) Validate() error {
+	n := p.N()
+	if n == nil || n.Sign() <= 0 {
+		return errors.New("srp: invalid parameters: missing modulus N")
+	}
+	if !n.ProbablyPrime(primeCertainty) {
+		return errors.New("srp: invalid parameters: N is not prime")
+	}
+	if p.g <= 1 {
+		return errors.New("srp: invalid parameters: g must be greater than 1")
+	}
+	one := big.NewInt(1)
+	g := big.NewInt(p.g)
+	if g.Cmp(n) >= 0 {
+		return errors.New("srp: invalid parameters: g must be smaller than N")
+	}
+	// For a safe prime N = 2q+1, the multiplicative group mod N has only
+	// four possible element orders: 1, 2, q and 2q. g must not fall into
+	// the order-1 or order-2 subgroup, nor into the order-q subgroup
+	// (g^q mod N == 1); only an element of the full order 2q = N-1 is an
+	// acceptable generator.
+	if new(big.Int).Exp(g, big.NewInt(2), n).Cmp(one) == 0 {
+		return errors.New("srp: invalid parameters: g does not generate the group modulo N")
+	}
+	q := new(big.Int).Rsh(new(big.Int).Sub(n, one), 1)
+	if new(big.Int).Exp(g, q, n).Cmp(one) == 0 {
+		return errors.New("srp: invalid parameters: g does not generate the group modulo N")
+	}
+	return nil
+}
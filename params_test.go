@@ -0,0 +1,74 @@
+package srp
+
+import (
+	"crypto"
+	"math/big"
+	"testing"
+)
+
+func TestBundledGroupsValidate(t *testing.T) {
+	groups := map[string]*Params{
+		"Group1024": Group1024,
+		"Group1536": Group1536,
+		"Group2048": Group2048,
+		"Group3072": Group3072,
+		"Group4096": Group4096,
+		"Group6144": Group6144,
+		"Group8192": Group8192,
+	}
+	for name, p := range groups {
+		if err := p.Validate(); err != nil {
+			t.Errorf("%s.Validate() = %v, want nil", name, err)
+		}
+	}
+}
+
+func TestGroupByBits(t *testing.T) {
+	cases := []struct {
+		bits int
+		want *Params
+	}{
+		{1024, Group1024},
+		{1536, Group1536},
+		{2048, Group2048},
+		{3072, Group3072},
+		{4096, Group4096},
+		{6144, Group6144},
+		{8192, Group8192},
+	}
+	for _, c := range cases {
+		got, err := GroupByBits(c.bits)
+		if err != nil {
+			t.Errorf("GroupByBits(%d) error: %v", c.bits, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("GroupByBits(%d) returned a different *Params than the bundled group", c.bits)
+		}
+	}
+	if _, err := GroupByBits(4242); err == nil {
+		t.Error("GroupByBits(4242) = nil error, want an error for an unbundled bit size")
+	}
+}
+
+// TestValidateRejectsOrderQGenerator guards against the class of bug where
+// a generator has only order q (not the full order N-1 = 2q) in the safe
+// prime's multiplicative group: g=2 is such an element for the bundled
+// 4096-bit group (unlike the 1024-bit group, where g=2 is a valid
+// full-order generator), and Validate must reject it.
+func TestValidateRejectsOrderQGenerator(t *testing.T) {
+	bad := newGroup(n4096Hex, 2)
+	if err := bad.Validate(); err == nil {
+		t.Error("Validate() accepted g=2 for the 4096-bit group, which only has order q there")
+	}
+	if err := Group4096.Validate(); err != nil {
+		t.Errorf("Group4096.Validate() = %v, want nil (g=5 is a full-order generator)", err)
+	}
+}
+
+func TestValidateRejectsSmallOrderGenerator(t *testing.T) {
+	p := NewParams(new(big.Int).Set(vecN), 1, crypto.SHA1)
+	if err := p.Validate(); err == nil {
+		t.Error("Validate() accepted g=1")
+	}
+}
@@ -0,0 +1,68 @@
+package srp
+
+import (
+	"crypto"
+	"math/big"
+)
+
+// This file exposes the SRP math as stateless primitives operating directly
+// on *big.Int, mirroring Erlang crypto's srp_value_B/5, srp_client_secret/6
+// and srp_server_secret/5. They make it straightforward to test this
+// package against RFC 5054 Appendix B test vectors, and let advanced users
+// build custom flows (e.g. SRP-6 instead of SRP-6a) without going through
+// the Client/Server state machines. Client and Server are themselves
+// implemented in terms of these primitives.
+
+// padToN left-pads b with zero bytes to the byte length of N, the PAD()
+// function from RFC 5054.
+func padToN(b []byte, N *big.Int) []byte {
+	size := (N.BitLen() + 7) / 8
+	if len(b) >= size {
+		return b
+	}
+	padded := make([]byte, size)
+	copy(padded[size-len(b):], b)
+	return padded
+}
+
+// hashWith returns H(data...), the concatenation of data hashed with h.
+func hashWith(h crypto.Hash, data ...[]byte) []byte {
+	hh := h.New()
+	for _, d := range data {
+		hh.Write(d)
+	}
+	return hh.Sum(nil)
+}
+
+// SRPMultiplier computes the SRP-6a multiplier k := H(N | PAD(g)).
+func SRPMultiplier(g, N *big.Int, h crypto.Hash) *big.Int {
+	return new(big.Int).SetBytes(hashWith(h, N.Bytes(), padToN(g.Bytes(), N)))
+}
+
+// SRPu computes the scrambling parameter u := H(PAD(A) | PAD(B)).
+func SRPu(A, B, N *big.Int, h crypto.Hash) *big.Int {
+	return new(big.Int).SetBytes(hashWith(h, padToN(A.Bytes(), N), padToN(B.Bytes(), N)))
+}
+
+// SRPValueB computes the server's public value B := (k*v + g^b) % N.
+func SRPValueB(k, v, g, b, N *big.Int) *big.Int {
+	kv := new(big.Int).Mod(new(big.Int).Mul(k, v), N)
+	gb := new(big.Int).Exp(g, b, N)
+	return new(big.Int).Mod(new(big.Int).Add(kv, gb), N)
+}
+
+// SRPClientSecret computes the client's premaster secret
+// S := (B - k*g^x)^(a + u*x) % N.
+func SRPClientSecret(a, u, B, k, x, g, N *big.Int) *big.Int {
+	kgx := new(big.Int).Mod(new(big.Int).Mul(k, new(big.Int).Exp(g, x, N)), N)
+	base := new(big.Int).Mod(new(big.Int).Sub(B, kgx), N)
+	exp := new(big.Int).Add(a, new(big.Int).Mul(u, x))
+	return new(big.Int).Exp(base, exp, N)
+}
+
+// SRPServerSecret computes the server's premaster secret
+// S := (A * v^u)^b % N.
+func SRPServerSecret(v, u, A, b, N *big.Int) *big.Int {
+	base := new(big.Int).Mod(new(big.Int).Mul(A, new(big.Int).Exp(v, u, N)), N)
+	return new(big.Int).Exp(base, b, N)
+}
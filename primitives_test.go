@@ -0,0 +1,81 @@
+package srp
+
+import (
+	"bytes"
+	"crypto"
+	"math/big"
+	"testing"
+)
+
+// Test vectors from RFC 5054 Appendix B (I = "alice", P = "password123",
+// group N1024/g=2, SHA-1), with a and b pinned to fixed values so A, B, u
+// and S are reproducible.
+var (
+	vecN, _ = new(big.Int).SetString(n1024Hex, 16)
+	vecG    = big.NewInt(2)
+	vecS, _ = new(big.Int).SetString("BEB25379D1A8581EB5A727673A2441EE", 16)
+	vecX, _ = new(big.Int).SetString("94B7555AABE9127CC58CCF4993DB6CF84D16C124", 16)
+	vecV, _ = new(big.Int).SetString("7E273DE8696FFC4F4E337D05B4B375BEB0DDE1569E8FA00A9886D8129BADA1F1822223CA1A605B530E379BA4729FDC59F105B4787E5186F5C671085A1447B52A48CF1970B4FB6F8400BBF4CEBFBB168152E08AB5EA53D15C1AFF87B2B9DA6E04E058AD51CC72BFC9033B564E26480D78E955A5E29E7AB245DB2BE315E2099AFB", 16)
+	vecK, _ = new(big.Int).SetString("7556AA045AEF2CDD07ABAF0F665C3E818913186F", 16)
+	vecA, _ = new(big.Int).SetString("60975527035CF2AD1989806F0407210BC81EDC04E2762A56AFD529DDDA2D4393", 16)
+	vecB, _ = new(big.Int).SetString("E487CB59D31AC550471E81F00F6928E01DDA08E974A004F49E61F5D105284D20", 16)
+	vecAA, _ = new(big.Int).SetString("61D5E490F6F1B79547B0704C436F523DD0E560F0C64115BB72557EC44352E8903211C04692272D8B2D1A5358A2CF1B6E0BFCF99F921530EC8E39356179EAE45E42BA92AEACED825171E1E8B9AF6D9C03E1327F44BE087EF06530E69F66615261EEF54073CA11CF5858F0EDFDFE15EFEAB349EF5D76988A3672FAC47B0769447B", 16)
+	vecBB, _ = new(big.Int).SetString("BD0C61512C692C0CB6D041FA01BB152D4916A1E77AF46AE105393011BAF38964DC46A0670DD125B95A981652236F99D9B681CBF87837EC996C6DA04453728610D0C6DDB58B318885D7D82C7F8DEB75CE7BD4FBAA37089E6F9C6059F388838E7A00030B331EB76840910440B1B27AAEAEEB4012B7D7665238A8E3FB004B117B58", 16)
+	vecU, _  = new(big.Int).SetString("CE38B9593487DA98554ED47D70A7AE5F462EF019", 16)
+	vecSS, _ = new(big.Int).SetString("B0DC82BABCF30674AE450C0287745E7990A3381F63B387AAF271A10D233861E359B48220F7C4693C9AE12B0A6F67809F0876E2D013800D6C41BB59B6D5979B5C00A172B4A2A5903A0BDCAF8A709585EB2AFAFA8F3499B200210DCC1F10EB33943CD67FC88A2F39A4BE5BEC4EC0A3212DC346D7E474B29EDE8A469FFECA686E5A", 16)
+)
+
+func TestComputeVerifierRFC5054Vector(t *testing.T) {
+	v := ComputeVerifier(Group1024, []byte("alice"), []byte("password123"), vecS.Bytes())
+	if !bytes.Equal(v, vecV.Bytes()) {
+		t.Fatalf("ComputeVerifier = %X, want %X", v, vecV.Bytes())
+	}
+}
+
+func TestSRPMultiplierRFC5054Vector(t *testing.T) {
+	k := SRPMultiplier(vecG, vecN, crypto.SHA1)
+	if k.Cmp(vecK) != 0 {
+		t.Fatalf("SRPMultiplier = %X, want %X", k, vecK)
+	}
+}
+
+func TestSRPValueBRFC5054Vector(t *testing.T) {
+	B := SRPValueB(vecK, vecV, vecG, vecB, vecN)
+	if B.Cmp(vecBB) != 0 {
+		t.Fatalf("SRPValueB = %X, want %X", B, vecBB)
+	}
+}
+
+func TestSRPuRFC5054Vector(t *testing.T) {
+	u := SRPu(vecAA, vecBB, vecN, crypto.SHA1)
+	if u.Cmp(vecU) != 0 {
+		t.Fatalf("SRPu = %X, want %X", u, vecU)
+	}
+}
+
+func TestSRPClientAndServerSecretRFC5054Vector(t *testing.T) {
+	cs := SRPClientSecret(vecA, vecU, vecBB, vecK, vecX, vecG, vecN)
+	if cs.Cmp(vecSS) != 0 {
+		t.Fatalf("SRPClientSecret = %X, want %X", cs, vecSS)
+	}
+	ss := SRPServerSecret(vecV, vecU, vecAA, vecB, vecN)
+	if ss.Cmp(vecSS) != 0 {
+		t.Fatalf("SRPServerSecret = %X, want %X", ss, vecSS)
+	}
+}
+
+func TestPadToN(t *testing.T) {
+	size := (vecN.BitLen() + 7) / 8
+	padded := padToN([]byte{0x01}, vecN)
+	if len(padded) != size {
+		t.Fatalf("padToN length = %d, want %d", len(padded), size)
+	}
+	if padded[size-1] != 0x01 {
+		t.Fatalf("padToN did not preserve trailing byte: %X", padded)
+	}
+	full := make([]byte, size)
+	full[0] = 0xFF
+	if !bytes.Equal(padToN(full, vecN), full) {
+		t.Fatal("padToN must not truncate a value already at full length")
+	}
+}
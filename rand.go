@@ -0,0 +1,41 @@
+package srp
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+// minPrivateValueLen is the minimum size, in bytes, of a randomly
+// generated private exponent (Client.a or Server.b). RFC 5054 §3
+// recommends at least 256 bits of entropy; 32 bytes matches that with
+// margin to spare for smaller groups.
+const minPrivateValueLen = 32
+
+// getRandomBytes returns n cryptographically strong random bytes, read
+// from crypto/rand. It panics if the system entropy source fails, which
+// should never happen in practice.
+func getRandomBytes(n int) []byte {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic("srp: failed to read random bytes: " + err.Error())
+	}
+	return b
+}
+
+// randPrivateValue returns a cryptographically strong private exponent
+// (the client's a or the server's b) for the group with modulus N, drawn
+// from crypto/rand at max(minPrivateValueLen, len(N)/8) bytes. Values that
+// reduce to 0 mod N are rejected and redrawn, since they would let an
+// attacker force a known A or B.
+func randPrivateValue(N *big.Int) *big.Int {
+	size := len(N.Bytes())
+	if size < minPrivateValueLen {
+		size = minPrivateValueLen
+	}
+	for {
+		v := new(big.Int).SetBytes(getRandomBytes(size))
+		if new(big.Int).Mod(v, N).Sign() != 0 {
+			return v
+		}
+	}
+}
@@ -1,46 +1,105 @@
 package srp
 
 import (
+	"crypto/subtle"
+	"errors"
 	"math/big"
 )
 
 // Server performs the server side computations of the SRP protocol
 type Server struct {
 	params *Params
+	I      []byte
 	b      []byte
 	B      []byte
+	A      []byte
 	v      []byte
 	s      []byte
 	S      []byte
 }
 
-// NewServer creates a new server instance for the given credentials
-func NewServer(v, s string) *Server {
-	p := NewDefaultParams()
+// NewServer creates a new server instance for the given user identity and
+// credentials, as produced by Client.GenerateVerifier or ComputeVerifier
+func NewServer(I, v, s []byte) *Server {
 	return &Server{
-		params: p,
-		v:      []byte(v),
-		s:      []byte(s),
+		params: NewDefaultParams().Clone(),
+		I:      I,
+		v:      v,
+		s:      s,
 	}
 }
 
-// NewServerWithParams creates a new server instance for the given credentials
-// using the custom group and hash parameters
-func NewServerWithParams(p *Params, v, s string) *Server {
+// NewServerWithParams creates a new server instance for the given user
+// identity and credentials, using the custom group and hash parameters. p
+// is cloned, so the caller is free to mutate the Params they passed in (or
+// reuse a bundled Group* value) without affecting this server, nor any
+// other concurrent session built from the same Params.
+func NewServerWithParams(p *Params, I, v, s []byte) *Server {
 	return &Server{
-		params: p,
-		v:      []byte(v),
-		s:      []byte(s),
+		params: p.Clone(),
+		I:      I,
+		v:      v,
+		s:      s,
 	}
 }
 
-// GenerateB generates the server's secret b and its public masked value B.
-// Then, returns the bytes of B
+// GenerateB generates the server's secret b and its public masked value,
+// defined as B := (k*v + g^b) % N, for k := H(N | PAD(g)). Then, returns
+// the bytes of B. b is drawn from crypto/rand, as described in
+// randPrivateValue.
 func (s *Server) GenerateB() []byte {
-	s.b = getRandomBytes(3)
-	b := big.NewInt(0).SetBytes(s.b)
+	n := s.params.N()
+	b := randPrivateValue(n)
+	s.b = b.Bytes()
 	g := big.NewInt(s.params.g)
-	s.B = big.NewInt(0).Exp(g, b, s.params.N).Bytes()
+	v := new(big.Int).SetBytes(s.v)
+	k := SRPMultiplier(g, n, s.params.H)
 
+	s.B = SRPValueB(k, v, g, b, n).Bytes()
 	return s.B
 }
+
+// ComputeS computes and returns the server's premaster secret, defined as
+//
+//	u := H(PAD(A) | PAD(B))
+//	S := (A * v^u)^b % N
+//
+// It aborts with an error if A mod N == 0.
+func (s *Server) ComputeS(A []byte) ([]byte, error) {
+	n := s.params.N()
+
+	An := new(big.Int).SetBytes(A)
+	if new(big.Int).Mod(An, n).Sign() == 0 {
+		return nil, errors.New("srp: abort: A mod N == 0")
+	}
+	s.A = A
+
+	Bn := new(big.Int).SetBytes(s.B)
+	u := SRPu(An, Bn, n, s.params.H)
+	v := new(big.Int).SetBytes(s.v)
+	b := new(big.Int).SetBytes(s.b)
+
+	s.S = SRPServerSecret(v, u, An, b, n).Bytes()
+	return s.S, nil
+}
+
+// VerifyM1 checks the client's evidence message against the server's own
+// computation, in constant time.
+func (s *Server) VerifyM1(m1 []byte) bool {
+	expected := computeM1(s.params, s.I, s.s, s.A, s.B, s.S)
+	return subtle.ConstantTimeCompare(expected, m1) == 1
+}
+
+// M2 computes and returns the server's evidence message, proving to the
+// client that it derived the same session key.
+func (s *Server) M2() []byte {
+	m1 := computeM1(s.params, s.I, s.s, s.A, s.B, s.S)
+	return computeM2(s.params, s.A, m1, s.S)
+}
+
+// Key returns the session key K, derived from the premaster secret S by
+// the server's configured Params.KDF. It is only meaningful after
+// ComputeS has succeeded.
+func (s *Server) Key() []byte {
+	return s.params.deriveKey(s.S)
+}
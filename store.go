@@ -0,0 +1,138 @@
+package srp
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrUserNotFound is returned by a UserStore when no record exists for a
+// given identity.
+var ErrUserNotFound = errors.New("srp: user not found")
+
+// UserStore persists the salt/verifier pairs produced during registration
+// and makes them available to an AuthServer during authentication. It is
+// the extension point for plugging SRP into an actual user database.
+type UserStore interface {
+	// Lookup returns the salt and verifier registered for I, as produced by
+	// Client.GenerateVerifier or ComputeVerifier. It returns ErrUserNotFound
+	// if I has no record.
+	Lookup(I []byte) (salt, verifier []byte, err error)
+
+	// Register stores the salt/verifier pair for I, overwriting any
+	// existing record.
+	Register(I, salt, verifier []byte) error
+
+	// RecordFailure is called by ServerSession.Fail, which the caller
+	// invokes after a failed evidence check (VerifyM1 does not call it),
+	// letting implementations track failed attempts and apply
+	// rate-limiting. Implementations with no such policy may no-op.
+	RecordFailure(I []byte) error
+}
+
+// userRecord is the data an InMemoryStore keeps per identity.
+type userRecord struct {
+	salt, verifier []byte
+	failures       int
+}
+
+// InMemoryStore is a UserStore backed by a map, suitable for tests and
+// small deployments. It is safe for concurrent use.
+type InMemoryStore struct {
+	mu      sync.RWMutex
+	records map[string]userRecord
+}
+
+// NewInMemoryStore returns an empty InMemoryStore.
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{records: make(map[string]userRecord)}
+}
+
+// Lookup implements UserStore.
+func (st *InMemoryStore) Lookup(I []byte) (salt, verifier []byte, err error) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	rec, ok := st.records[string(I)]
+	if !ok {
+		return nil, nil, ErrUserNotFound
+	}
+	return rec.salt, rec.verifier, nil
+}
+
+// Register implements UserStore.
+func (st *InMemoryStore) Register(I, salt, verifier []byte) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.records[string(I)] = userRecord{salt: salt, verifier: verifier}
+	return nil
+}
+
+// RecordFailure implements UserStore, incrementing a per-identity failure
+// counter. It returns ErrUserNotFound if I has no record.
+func (st *InMemoryStore) RecordFailure(I []byte) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	rec, ok := st.records[string(I)]
+	if !ok {
+		return ErrUserNotFound
+	}
+	rec.failures++
+	st.records[string(I)] = rec
+	return nil
+}
+
+// Failures returns the number of recorded failed attempts for I, for
+// callers implementing a rate-limiting or lockout policy.
+func (st *InMemoryStore) Failures(I []byte) int {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.records[string(I)].failures
+}
+
+// AuthServer dispatches per-connection authentication sessions backed by a
+// UserStore, separating the transport/connection lifecycle from the SRP
+// math in Server.
+type AuthServer struct {
+	params *Params
+	store  UserStore
+}
+
+// NewAuthServer returns an AuthServer using the package's default group and
+// hash, backed by store.
+func NewAuthServer(store UserStore) *AuthServer {
+	return &AuthServer{params: NewDefaultParams().Clone(), store: store}
+}
+
+// NewAuthServerWithParams returns an AuthServer using the given group and
+// hash parameters, backed by store. p is cloned, so the caller is free to
+// mutate the Params they passed in (or reuse a bundled Group* value)
+// without affecting this AuthServer.
+func NewAuthServerWithParams(p *Params, store UserStore) *AuthServer {
+	return &AuthServer{params: p.Clone(), store: store}
+}
+
+// ServerSession is a single client's authentication attempt: a Server bound
+// to the record an AuthServer looked up, with b/B already generated.
+type ServerSession struct {
+	*Server
+	store UserStore
+}
+
+// Session looks up I in the store and returns a ServerSession with a fresh
+// b/B pair, ready to receive the client's A and complete the handshake. It
+// returns ErrUserNotFound (or whatever error the store returns) if I is not
+// registered.
+func (as *AuthServer) Session(I []byte) (*ServerSession, error) {
+	salt, verifier, err := as.store.Lookup(I)
+	if err != nil {
+		return nil, err
+	}
+	s := NewServerWithParams(as.params, I, verifier, salt)
+	s.GenerateB()
+	return &ServerSession{Server: s, store: as.store}, nil
+}
+
+// Fail reports a failed authentication attempt for this session's identity
+// to the backing UserStore, e.g. after VerifyM1 returns false.
+func (ss *ServerSession) Fail() error {
+	return ss.store.RecordFailure(ss.I)
+}
@@ -0,0 +1,99 @@
+package srp
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestInMemoryStoreRegisterLookup(t *testing.T) {
+	st := NewInMemoryStore()
+	I := []byte("alice")
+	salt, verifier := []byte("salt"), []byte("verifier")
+
+	if err := st.Register(I, salt, verifier); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	gotSalt, gotVerifier, err := st.Lookup(I)
+	if err != nil {
+		t.Fatalf("Lookup: %v", err)
+	}
+	if !bytes.Equal(gotSalt, salt) || !bytes.Equal(gotVerifier, verifier) {
+		t.Fatalf("Lookup = (%X, %X), want (%X, %X)", gotSalt, gotVerifier, salt, verifier)
+	}
+}
+
+func TestInMemoryStoreLookupUnregistered(t *testing.T) {
+	st := NewInMemoryStore()
+	if _, _, err := st.Lookup([]byte("bob")); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("Lookup on an unregistered identity = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestInMemoryStoreRecordFailure(t *testing.T) {
+	st := NewInMemoryStore()
+	I := []byte("alice")
+	if err := st.Register(I, []byte("salt"), []byte("verifier")); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	if err := st.RecordFailure(I); err != nil {
+		t.Fatalf("RecordFailure: %v", err)
+	}
+	if err := st.RecordFailure(I); err != nil {
+		t.Fatalf("RecordFailure: %v", err)
+	}
+	if got := st.Failures(I); got != 2 {
+		t.Fatalf("Failures = %d, want 2", got)
+	}
+
+	if err := st.RecordFailure([]byte("bob")); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("RecordFailure on an unregistered identity = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestAuthServerSessionHandshakeAndFail(t *testing.T) {
+	I, P := []byte("alice"), []byte("password123")
+	client := NewClient(I, P)
+	salt, verifier, err := client.GenerateVerifier()
+	if err != nil {
+		t.Fatalf("GenerateVerifier: %v", err)
+	}
+
+	st := NewInMemoryStore()
+	if err := st.Register(I, salt, verifier); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	as := NewAuthServer(st)
+
+	session, err := as.Session(I)
+	if err != nil {
+		t.Fatalf("Session: %v", err)
+	}
+
+	A := client.GenerateA()
+	if _, err := client.SessionKey(salt, session.B); err != nil {
+		t.Fatalf("client.SessionKey: %v", err)
+	}
+	if _, err := session.ComputeS(A); err != nil {
+		t.Fatalf("session.ComputeS: %v", err)
+	}
+	if !session.VerifyM1(client.M1()) {
+		t.Fatal("session rejected a genuine client M1")
+	}
+
+	if err := session.Fail(); err != nil {
+		t.Fatalf("Fail: %v", err)
+	}
+	if got := st.Failures(I); got != 1 {
+		t.Fatalf("Failures after Fail = %d, want 1", got)
+	}
+}
+
+func TestAuthServerSessionUnregisteredIdentity(t *testing.T) {
+	as := NewAuthServer(NewInMemoryStore())
+	if _, err := as.Session([]byte("nobody")); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("Session for an unregistered identity = %v, want ErrUserNotFound", err)
+	}
+}
@@ -0,0 +1,38 @@
+package srp
+
+import "math/big"
+
+// defaultSaltLen is the length, in bytes, of a randomly generated salt.
+const defaultSaltLen = 16
+
+// computeX computes x := H(s | H(I | ":" | P)), the private key derived
+// from a user's identity, password and salt.
+func computeX(p *Params, I, P, salt []byte) *big.Int {
+	ip := p.hash(I, []byte(":"), P)
+	return new(big.Int).SetBytes(p.hash(salt, ip))
+}
+
+// ComputeVerifier computes v := g^x % N, for x := H(s | H(I | ":" | P)),
+// the verifier a registration server stores for a given identity and
+// password. It lets a registration server validate or regenerate a
+// verifier without going through a Client.
+func ComputeVerifier(p *Params, I, P, salt []byte) []byte {
+	x := computeX(p, I, P, salt)
+	return new(big.Int).Exp(big.NewInt(p.g), x, p.N()).Bytes()
+}
+
+// GenerateVerifier computes a new random salt and the verifier matching the
+// client's configured identity and password, for the "User registration"
+// flow described in the package doc.
+func (c *Client) GenerateVerifier() (salt, verifier []byte, err error) {
+	salt = getRandomBytes(defaultSaltLen)
+	return salt, c.GenerateVerifierWithSalt(salt), nil
+}
+
+// GenerateVerifierWithSalt computes the verifier matching the client's
+// configured identity and password for a caller-supplied salt. Unlike
+// GenerateVerifier, it is deterministic, which makes it useful for testing
+// against known test vectors.
+func (c *Client) GenerateVerifierWithSalt(salt []byte) []byte {
+	return ComputeVerifier(c.params, c.I, c.P, salt)
+}
@@ -0,0 +1,309 @@
+package srp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// This file implements a self-describing binary codec for the five SRP
+// protocol messages, so callers can layer the handshake over TCP, a
+// WebSocket or any other io.ReadWriter without hand-rolling framing.
+//
+// Every message starts with a one-byte type tag, followed by its fields in
+// order, each as a big-endian uint16 length prefix followed by that many
+// bytes. A and B are PAD()-ed to len(N) bytes by Writer before they go on
+// the wire, so a peer that decodes them gets values of the length PAD()
+// requires for its own u/k computations, with no separate padding step.
+
+// Message type tags, written as the first byte of every encoded message.
+const (
+	msgClientHello byte = iota + 1
+	msgServerHello
+	msgClientKeyExchange
+	msgClientProof
+	msgServerProof
+)
+
+// maxFieldLen is the largest field size the uint16 length prefix can
+// represent.
+const maxFieldLen = 1<<16 - 1
+
+// ClientHello carries the client's identity, the first message of the
+// handshake.
+type ClientHello struct {
+	I []byte
+}
+
+// ServerHello carries the group parameters, the user's salt and the
+// server's public value B.
+type ServerHello struct {
+	N *big.Int
+	G int64
+	S []byte
+	B []byte
+}
+
+// ClientKeyExchange carries the client's public value A.
+type ClientKeyExchange struct {
+	A []byte
+}
+
+// ClientProof carries the client's evidence message M1.
+type ClientProof struct {
+	M1 []byte
+}
+
+// ServerProof carries the server's evidence message M2.
+type ServerProof struct {
+	M2 []byte
+}
+
+// writeType writes a message's type tag.
+func writeType(w io.Writer, typ byte) error {
+	_, err := w.Write([]byte{typ})
+	return err
+}
+
+// expectType reads a message's type tag and checks it against want.
+func expectType(r io.Reader, want byte) error {
+	var tag [1]byte
+	if _, err := io.ReadFull(r, tag[:]); err != nil {
+		return err
+	}
+	if tag[0] != want {
+		return fmt.Errorf("srp: wire: expected message type %d, got %d", want, tag[0])
+	}
+	return nil
+}
+
+// writeField writes b as a uint16 length prefix followed by its bytes.
+func writeField(w io.Writer, b []byte) error {
+	if len(b) > maxFieldLen {
+		return errors.New("srp: wire: field too large for a uint16 length prefix")
+	}
+	var lenBuf [2]byte
+	binary.BigEndian.PutUint16(lenBuf[:], uint16(len(b)))
+	if _, err := w.Write(lenBuf[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+// readField reads a uint16 length prefix and that many bytes.
+func readField(r io.Reader) ([]byte, error) {
+	var lenBuf [2]byte
+	if _, err := io.ReadFull(r, lenBuf[:]); err != nil {
+		return nil, err
+	}
+	b := make([]byte, binary.BigEndian.Uint16(lenBuf[:]))
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Encode writes m to w.
+func (m *ClientHello) Encode(w io.Writer) error {
+	if err := writeType(w, msgClientHello); err != nil {
+		return err
+	}
+	return writeField(w, m.I)
+}
+
+// DecodeClientHello reads a ClientHello from r.
+func DecodeClientHello(r io.Reader) (*ClientHello, error) {
+	if err := expectType(r, msgClientHello); err != nil {
+		return nil, err
+	}
+	I, err := readField(r)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientHello{I: I}, nil
+}
+
+// Encode writes m to w.
+func (m *ServerHello) Encode(w io.Writer) error {
+	if err := writeType(w, msgServerHello); err != nil {
+		return err
+	}
+	if err := writeField(w, m.N.Bytes()); err != nil {
+		return err
+	}
+	if err := writeField(w, big.NewInt(m.G).Bytes()); err != nil {
+		return err
+	}
+	if err := writeField(w, m.S); err != nil {
+		return err
+	}
+	return writeField(w, m.B)
+}
+
+// DecodeServerHello reads a ServerHello from r.
+func DecodeServerHello(r io.Reader) (*ServerHello, error) {
+	if err := expectType(r, msgServerHello); err != nil {
+		return nil, err
+	}
+	n, err := readField(r)
+	if err != nil {
+		return nil, err
+	}
+	g, err := readField(r)
+	if err != nil {
+		return nil, err
+	}
+	s, err := readField(r)
+	if err != nil {
+		return nil, err
+	}
+	b, err := readField(r)
+	if err != nil {
+		return nil, err
+	}
+	return &ServerHello{
+		N: new(big.Int).SetBytes(n),
+		G: new(big.Int).SetBytes(g).Int64(),
+		S: s,
+		B: b,
+	}, nil
+}
+
+// Encode writes m to w.
+func (m *ClientKeyExchange) Encode(w io.Writer) error {
+	if err := writeType(w, msgClientKeyExchange); err != nil {
+		return err
+	}
+	return writeField(w, m.A)
+}
+
+// DecodeClientKeyExchange reads a ClientKeyExchange from r.
+func DecodeClientKeyExchange(r io.Reader) (*ClientKeyExchange, error) {
+	if err := expectType(r, msgClientKeyExchange); err != nil {
+		return nil, err
+	}
+	A, err := readField(r)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientKeyExchange{A: A}, nil
+}
+
+// Encode writes m to w.
+func (m *ClientProof) Encode(w io.Writer) error {
+	if err := writeType(w, msgClientProof); err != nil {
+		return err
+	}
+	return writeField(w, m.M1)
+}
+
+// DecodeClientProof reads a ClientProof from r.
+func DecodeClientProof(r io.Reader) (*ClientProof, error) {
+	if err := expectType(r, msgClientProof); err != nil {
+		return nil, err
+	}
+	M1, err := readField(r)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientProof{M1: M1}, nil
+}
+
+// Encode writes m to w.
+func (m *ServerProof) Encode(w io.Writer) error {
+	if err := writeType(w, msgServerProof); err != nil {
+		return err
+	}
+	return writeField(w, m.M2)
+}
+
+// DecodeServerProof reads a ServerProof from r.
+func DecodeServerProof(r io.Reader) (*ServerProof, error) {
+	if err := expectType(r, msgServerProof); err != nil {
+		return nil, err
+	}
+	M2, err := readField(r)
+	if err != nil {
+		return nil, err
+	}
+	return &ServerProof{M2: M2}, nil
+}
+
+// Writer encodes SRP messages onto an underlying io.Writer (typically one
+// side of an io.ReadWriter such as a net.Conn), PAD()-ing A and B to len(N)
+// bytes before they go on the wire.
+type Writer struct {
+	w io.Writer
+}
+
+// NewWriter returns a Writer that writes to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// WriteClientHello writes a ClientHello carrying I.
+func (w *Writer) WriteClientHello(I []byte) error {
+	return (&ClientHello{I: I}).Encode(w.w)
+}
+
+// WriteServerHello writes a ServerHello, PAD()-ing B to len(N) bytes.
+func (w *Writer) WriteServerHello(N *big.Int, g int64, s, B []byte) error {
+	m := &ServerHello{N: N, G: g, S: s, B: padToN(B, N)}
+	return m.Encode(w.w)
+}
+
+// WriteClientKeyExchange writes a ClientKeyExchange, PAD()-ing A to len(N)
+// bytes.
+func (w *Writer) WriteClientKeyExchange(N *big.Int, A []byte) error {
+	m := &ClientKeyExchange{A: padToN(A, N)}
+	return m.Encode(w.w)
+}
+
+// WriteClientProof writes a ClientProof carrying M1.
+func (w *Writer) WriteClientProof(M1 []byte) error {
+	return (&ClientProof{M1: M1}).Encode(w.w)
+}
+
+// WriteServerProof writes a ServerProof carrying M2.
+func (w *Writer) WriteServerProof(M2 []byte) error {
+	return (&ServerProof{M2: M2}).Encode(w.w)
+}
+
+// Reader decodes SRP messages from an underlying io.Reader (typically one
+// side of an io.ReadWriter such as a net.Conn).
+type Reader struct {
+	r io.Reader
+}
+
+// NewReader returns a Reader that reads from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: r}
+}
+
+// ReadClientHello reads a ClientHello.
+func (r *Reader) ReadClientHello() (*ClientHello, error) {
+	return DecodeClientHello(r.r)
+}
+
+// ReadServerHello reads a ServerHello.
+func (r *Reader) ReadServerHello() (*ServerHello, error) {
+	return DecodeServerHello(r.r)
+}
+
+// ReadClientKeyExchange reads a ClientKeyExchange.
+func (r *Reader) ReadClientKeyExchange() (*ClientKeyExchange, error) {
+	return DecodeClientKeyExchange(r.r)
+}
+
+// ReadClientProof reads a ClientProof.
+func (r *Reader) ReadClientProof() (*ClientProof, error) {
+	return DecodeClientProof(r.r)
+}
+
+// ReadServerProof reads a ServerProof.
+func (r *Reader) ReadServerProof() (*ServerProof, error) {
+	return DecodeServerProof(r.r)
+}
@@ -0,0 +1,192 @@
+package srp
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestClientHelloRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	want := &ClientHello{I: []byte("alice")}
+	if err := want.Encode(&buf); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	got, err := DecodeClientHello(&buf)
+	if err != nil {
+		t.Fatalf("DecodeClientHello: %v", err)
+	}
+	if !bytes.Equal(got.I, want.I) {
+		t.Errorf("I = %X, want %X", got.I, want.I)
+	}
+}
+
+func TestServerHelloRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	want := &ServerHello{N: vecN, G: 2, S: vecS.Bytes(), B: vecBB.Bytes()}
+	if err := want.Encode(&buf); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	got, err := DecodeServerHello(&buf)
+	if err != nil {
+		t.Fatalf("DecodeServerHello: %v", err)
+	}
+	if got.N.Cmp(want.N) != 0 {
+		t.Errorf("N = %X, want %X", got.N, want.N)
+	}
+	if got.G != want.G {
+		t.Errorf("G = %d, want %d", got.G, want.G)
+	}
+	if !bytes.Equal(got.S, want.S) {
+		t.Errorf("S = %X, want %X", got.S, want.S)
+	}
+	if !bytes.Equal(got.B, want.B) {
+		t.Errorf("B = %X, want %X", got.B, want.B)
+	}
+}
+
+func TestClientKeyExchangeRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	want := &ClientKeyExchange{A: vecAA.Bytes()}
+	if err := want.Encode(&buf); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	got, err := DecodeClientKeyExchange(&buf)
+	if err != nil {
+		t.Fatalf("DecodeClientKeyExchange: %v", err)
+	}
+	if !bytes.Equal(got.A, want.A) {
+		t.Errorf("A = %X, want %X", got.A, want.A)
+	}
+}
+
+func TestClientProofRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	want := &ClientProof{M1: []byte{0xDE, 0xAD, 0xBE, 0xEF}}
+	if err := want.Encode(&buf); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	got, err := DecodeClientProof(&buf)
+	if err != nil {
+		t.Fatalf("DecodeClientProof: %v", err)
+	}
+	if !bytes.Equal(got.M1, want.M1) {
+		t.Errorf("M1 = %X, want %X", got.M1, want.M1)
+	}
+}
+
+func TestServerProofRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	want := &ServerProof{M2: []byte{0xCA, 0xFE, 0xBA, 0xBE}}
+	if err := want.Encode(&buf); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	got, err := DecodeServerProof(&buf)
+	if err != nil {
+		t.Fatalf("DecodeServerProof: %v", err)
+	}
+	if !bytes.Equal(got.M2, want.M2) {
+		t.Errorf("M2 = %X, want %X", got.M2, want.M2)
+	}
+}
+
+func TestDecodeRejectsWrongMessageType(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (&ClientHello{I: []byte("alice")}).Encode(&buf); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if _, err := DecodeServerHello(&buf); err == nil {
+		t.Fatal("DecodeServerHello accepted a ClientHello's bytes")
+	}
+}
+
+// TestWriterPadsToN checks that Writer PAD()s A and B to len(N) bytes on
+// the wire, as the request asked for, so that cross-implementation u/k
+// computations match without a separate padding step on the reading side.
+func TestWriterPadsToN(t *testing.T) {
+	n := vecN
+	size := (n.BitLen() + 7) / 8
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	short := []byte{0x01, 0x02}
+	if err := w.WriteServerHello(n, 2, []byte{0x01}, short); err != nil {
+		t.Fatalf("WriteServerHello: %v", err)
+	}
+	sh, err := NewReader(&buf).ReadServerHello()
+	if err != nil {
+		t.Fatalf("ReadServerHello: %v", err)
+	}
+	if len(sh.B) != size {
+		t.Fatalf("ServerHello.B length = %d, want %d (len(N))", len(sh.B), size)
+	}
+	if !bytes.Equal(sh.B[size-len(short):], short) {
+		t.Fatalf("ServerHello.B = %X, want %X left-padded", sh.B, short)
+	}
+
+	buf.Reset()
+	if err := w.WriteClientKeyExchange(n, short); err != nil {
+		t.Fatalf("WriteClientKeyExchange: %v", err)
+	}
+	kex, err := NewReader(&buf).ReadClientKeyExchange()
+	if err != nil {
+		t.Fatalf("ReadClientKeyExchange: %v", err)
+	}
+	if len(kex.A) != size {
+		t.Fatalf("ClientKeyExchange.A length = %d, want %d (len(N))", len(kex.A), size)
+	}
+	if !bytes.Equal(kex.A[size-len(short):], short) {
+		t.Fatalf("ClientKeyExchange.A = %X, want %X left-padded", kex.A, short)
+	}
+}
+
+// TestReaderWriterFullHandshake drives a Writer/Reader pair over the same
+// buffer through every message in order, the shape a real transport would
+// use.
+func TestReaderWriterFullHandshake(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	r := NewReader(&buf)
+
+	if err := w.WriteClientHello([]byte("alice")); err != nil {
+		t.Fatalf("WriteClientHello: %v", err)
+	}
+	if err := w.WriteServerHello(vecN, 2, vecS.Bytes(), vecBB.Bytes()); err != nil {
+		t.Fatalf("WriteServerHello: %v", err)
+	}
+	if err := w.WriteClientKeyExchange(vecN, vecAA.Bytes()); err != nil {
+		t.Fatalf("WriteClientKeyExchange: %v", err)
+	}
+	if err := w.WriteClientProof([]byte("m1")); err != nil {
+		t.Fatalf("WriteClientProof: %v", err)
+	}
+	if err := w.WriteServerProof([]byte("m2")); err != nil {
+		t.Fatalf("WriteServerProof: %v", err)
+	}
+
+	hello, err := r.ReadClientHello()
+	if err != nil || string(hello.I) != "alice" {
+		t.Fatalf("ReadClientHello = %+v, %v", hello, err)
+	}
+	if _, err := r.ReadServerHello(); err != nil {
+		t.Fatalf("ReadServerHello: %v", err)
+	}
+	if _, err := r.ReadClientKeyExchange(); err != nil {
+		t.Fatalf("ReadClientKeyExchange: %v", err)
+	}
+	proof, err := r.ReadClientProof()
+	if err != nil || string(proof.M1) != "m1" {
+		t.Fatalf("ReadClientProof = %+v, %v", proof, err)
+	}
+	sproof, err := r.ReadServerProof()
+	if err != nil || string(sproof.M2) != "m2" {
+		t.Fatalf("ReadServerProof = %+v, %v", sproof, err)
+	}
+}
+
+func TestWriteFieldRejectsOversizedField(t *testing.T) {
+	var buf bytes.Buffer
+	oversized := make([]byte, maxFieldLen+1)
+	if err := writeField(&buf, oversized); err == nil {
+		t.Fatal("writeField accepted a field longer than a uint16 can express")
+	}
+}